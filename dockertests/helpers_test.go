@@ -87,15 +87,11 @@ func purgeContainer(t *testing.T, resource *dockertest.Resource) {
 	}
 }
 
-// Connects to RPC Server and returns RPC client.
-//
-// Uses pool.Retry() to first establish a TLS connection to wait for RPC server start.
-// RPC Server certificate is obtained and used to estalish RPC client connection.
-func rpcConnect(t *testing.T, pool *dockertest.Pool, network *dockertest.Network, resource *dockertest.Resource) *rpcclient.Client {
-	rpcServerIp := resource.GetIPInNetwork(network)
-	rpcAddress := rpcServerIp + ":8334"
-
-	// Retry TLS Connect since container might be starting up
+// Fetches the RPC server's self-signed TLS certificate by dialing it
+// directly, retrying with pool.Retry() since the container might still be
+// starting up. Centralized here so individual tests/helpers building their
+// own rpcclient.ConnConfig don't have to reimplement it.
+func fetchRPCServerCert(t *testing.T, pool *dockertest.Pool, rpcAddress string) []byte {
 	var tlsConn *tls.Conn
 	var tlsConnErr error
 	tlsConnErr = pool.Retry(func() error {
@@ -110,8 +106,8 @@ func rpcConnect(t *testing.T, pool *dockertest.Pool, network *dockertest.Network
 	if tlsConnErr != nil {
 		t.Fatalf("Failed to TLS connect, err %s", tlsConnErr)
 	}
+	defer tlsConn.Close()
 
-	// Store RPC Server certificates so that rpcclient can use it
 	var rpcServerCerts bytes.Buffer
 	for _, cert := range tlsConn.ConnectionState().PeerCertificates {
 		err := pem.Encode(&rpcServerCerts, &pem.Block{
@@ -122,14 +118,22 @@ func rpcConnect(t *testing.T, pool *dockertest.Pool, network *dockertest.Network
 			t.Fatalf("Failed to save to memory RPC Server certificates, err %s", err)
 		}
 	}
-	tlsConn.Close()
+	return rpcServerCerts.Bytes()
+}
+
+// Connects to RPC Server and returns RPC client.
+//
+// Uses pool.Retry() to first establish a TLS connection to wait for RPC server start.
+// RPC Server certificate is obtained and used to estalish RPC client connection.
+func rpcConnect(t *testing.T, pool *dockertest.Pool, network *dockertest.Network, resource *dockertest.Resource) *rpcclient.Client {
+	rpcAddress := resource.GetIPInNetwork(network) + ":8334"
 
 	connCfg := &rpcclient.ConnConfig{
 		Host:                rpcAddress,
 		Endpoint:            "ws",
 		User:                "localuser",
 		Pass:                "localuserpwd",
-		Certificates:        rpcServerCerts.Bytes(),
+		Certificates:        fetchRPCServerCert(t, pool, rpcAddress),
 		DisableConnectOnNew: true,
 	}
 
@@ -0,0 +1,272 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dockertests
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/ory/dockertest/v3"
+)
+
+// A CA plus a leaf certificate/key pair signed by it, all PEM encoded so
+// they can be written to disk and bind-mounted into a container.
+type testCertPair struct {
+	caCertPEM []byte
+
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// Generates a self-signed ECDSA CA and a leaf certificate signed by it,
+// valid for the given IP address (used as the container's TLS identity).
+func generateCertPair(t *testing.T, ip net.IP) testCertPair {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate CA key: %s", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "btcd-dockertests CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Could not create CA certificate: %s", err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("Could not parse CA certificate: %s", err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate leaf key: %s", err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "btcd-dockertests"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{ip},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Could not create leaf certificate: %s", err)
+	}
+	leafKeyDER, err := x509.MarshalECPrivateKey(leafKey)
+	if err != nil {
+		t.Fatalf("Could not marshal leaf key: %s", err)
+	}
+
+	return testCertPair{
+		caCertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		certPEM:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+		keyPEM:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: leafKeyDER}),
+	}
+}
+
+// Writes a server cert pair plus the trusted client CA into dir, overwriting
+// whatever was there before, so they can be bind-mounted into a container.
+func writeCertFiles(t *testing.T, dir string, server testCertPair) {
+	files := map[string][]byte{
+		"server.pem":   server.certPEM,
+		"server.key":   server.keyPEM,
+		"clientca.pem": server.caCertPEM,
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), contents, 0o600); err != nil {
+			t.Fatalf("Could not write %s: %s", name, err)
+		}
+	}
+}
+
+// Starts the btcd container with mutual-TLS client authentication enabled
+// (--rpccert/--rpckey/--rpcclientca/--rpcauthtype=mtls). The certs/keys are
+// bind-mounted from a temporary host directory into the container at
+// /certs.
+//
+// The server certificate's IP SAN has to match whatever IP Docker assigns
+// the container on network, which isn't known until after it starts. So
+// this starts the container once with a placeholder cert purely to learn
+// its IP, regenerates the server cert for that IP, rewrites it into the
+// (still bind-mounted) cert directory, and restarts the container so btcd
+// picks up the corrected cert/key on its next startup. This mirrors how
+// btcd's own certgen includes the node's real interface addresses, which is
+// why rpcConnect's harvested-certificate approach works elsewhere in this
+// package.
+//
+// The tests in this file only pass once --rpcclientca/--rpcauthtype=mtls are
+// wired into config.go/rpcserver.go using buildTLSConfig, and ConnConfig
+// grows ClientCertificates/ClientKey wired into the dial path using
+// clientTLSCertificate; the first two live in btcd's daemon entry point and
+// the last two in rpcclient's connection code, none of which is part of this
+// checkout. See TestBuildTLSConfigMTLS and
+// TestClientTLSCertificate for coverage of that logic in the meantime.
+func startBtcdWithMTLS(t *testing.T, pool *dockertest.Pool, network *dockertest.Network) (*dockertest.Resource, testCertPair) {
+	certDir, err := os.MkdirTemp("", "btcd-dockertests-mtls")
+	if err != nil {
+		t.Fatalf("Could not create cert dir: %s", err)
+	}
+
+	placeholder := generateCertPair(t, net.ParseIP("127.0.0.1"))
+	writeCertFiles(t, certDir, placeholder)
+
+	options := &dockertest.RunOptions{
+		Repository: "btcd-dockertests",
+		Tag:        "latest",
+		Networks:   []*dockertest.Network{network},
+		Mounts:     []string{certDir + ":/certs"},
+		Cmd: []string{
+			"--rpcuser=localuser",
+			"--rpcpass=localuserpwd",
+			"--rpclisten=0.0.0.0",
+			"--rpccert=/certs/server.pem",
+			"--rpckey=/certs/server.key",
+			"--rpcclientca=/certs/clientca.pem",
+			"--rpcauthtype=mtls",
+		},
+	}
+
+	resource, err := pool.RunWithOptions(options)
+	if err != nil {
+		t.Fatalf("Could not start resource: %s", err)
+	}
+
+	ip := net.ParseIP(resource.GetIPInNetwork(network))
+	server := generateCertPair(t, ip)
+	writeCertFiles(t, certDir, server)
+
+	if err := pool.Client.RestartContainer(resource.Container.ID, 10); err != nil {
+		t.Fatalf("Could not restart container with the real server cert: %s", err)
+	}
+
+	return resource, server
+}
+
+// Waits for the mTLS-enabled RPC port to accept TCP connections.
+func waitForRPCPort(t *testing.T, pool *dockertest.Pool, rpcAddress string) {
+	err := pool.Retry(func() error {
+		conn, err := net.Dial("tcp", rpcAddress)
+		if err != nil {
+			t.Logf("Not able to connect to %s, err %s", rpcAddress, err)
+			return err
+		}
+		conn.Close()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RPC port not open, err %s", err)
+	}
+}
+
+func TestMTLSRejectsConnectionWithoutClientCert(t *testing.T) {
+	pool, network := createBtcdNetwork(t)
+	defer purgeNetwork(t, network)
+
+	resource, _ := startBtcdWithMTLS(t, pool, network)
+	defer purgeContainer(t, resource)
+
+	go logContainer(t, pool, resource)
+
+	rpcAddress := resource.GetIPInNetwork(network) + ":8334"
+	waitForRPCPort(t, pool, rpcAddress)
+
+	_, err := tls.Dial("tcp", rpcAddress, &tls.Config{
+		InsecureSkipVerify: true,
+	})
+	if err == nil {
+		t.Fatalf("Expected handshake to fail without a client certificate")
+	}
+}
+
+func TestMTLSAcceptsKnownClientCert(t *testing.T) {
+	pool, network := createBtcdNetwork(t)
+	defer purgeNetwork(t, network)
+
+	resource, server := startBtcdWithMTLS(t, pool, network)
+	defer purgeContainer(t, resource)
+
+	go logContainer(t, pool, resource)
+
+	rpcAddress := resource.GetIPInNetwork(network) + ":8334"
+	waitForRPCPort(t, pool, rpcAddress)
+
+	// The server's leaf cert is signed by the CA written as clientca.pem and
+	// carries the client-auth usage, so it is a client cert the server trusts.
+	connCfg := &rpcclient.ConnConfig{
+		Host:                rpcAddress,
+		Endpoint:            "ws",
+		User:                "localuser",
+		Pass:                "localuserpwd",
+		Certificates:        server.caCertPEM,
+		ClientCertificates:  server.certPEM,
+		ClientKey:           server.keyPEM,
+		DisableConnectOnNew: true,
+	}
+
+	rpcClient, err := rpcclient.New(connCfg, nil)
+	if err != nil {
+		t.Fatalf("Failed to initialize rpc client, err %s", err)
+	}
+	defer rpcClient.Shutdown()
+
+	if err := rpcClient.Connect(1); err != nil {
+		t.Fatalf("Failed to connect with a known client cert, err %s", err)
+	}
+
+	if _, err := rpcClient.GetBlockCount(); err != nil {
+		t.Fatalf("Failed to perform RPC call GetBlockCount, err %s", err)
+	}
+}
+
+func TestMTLSRejectsForeignClientCert(t *testing.T) {
+	pool, network := createBtcdNetwork(t)
+	defer purgeNetwork(t, network)
+
+	resource, _ := startBtcdWithMTLS(t, pool, network)
+	defer purgeContainer(t, resource)
+
+	// A client cert signed by an unrelated CA, not the one the server was
+	// told to trust via --rpcclientca.
+	foreign := generateCertPair(t, net.ParseIP("127.0.0.1"))
+
+	go logContainer(t, pool, resource)
+
+	rpcAddress := resource.GetIPInNetwork(network) + ":8334"
+	waitForRPCPort(t, pool, rpcAddress)
+
+	clientCert, err := tls.X509KeyPair(foreign.certPEM, foreign.keyPEM)
+	if err != nil {
+		t.Fatalf("Could not load foreign client cert: %s", err)
+	}
+
+	conn, err := tls.Dial("tcp", rpcAddress, &tls.Config{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{clientCert},
+	})
+	if err == nil {
+		conn.Close()
+		t.Fatalf("Expected handshake to fail for a client cert signed by a foreign CA")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dockertests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/rpcclient/pool"
+	dc "github.com/ory/dockertest/v3/docker"
+)
+
+func TestPoolFailsOverWhenLeaderIsKilled(t *testing.T) {
+	poolClient, network := createBtcdNetwork(t)
+	defer purgeNetwork(t, network)
+
+	resources, clients := startBtcdCluster(t, poolClient, network, 3, "--regtest")
+	defer func() {
+		for _, resource := range resources {
+			purgeContainer(t, resource)
+		}
+	}()
+	for _, resource := range resources {
+		go logContainer(t, poolClient, resource)
+	}
+	defer func() {
+		for _, client := range clients {
+			client.Shutdown()
+		}
+	}()
+
+	if _, err := clients[0].Generate(1); err != nil {
+		t.Fatalf("Could not generate block: %s", err)
+	}
+
+	var configs []*rpcclient.ConnConfig
+	for _, resource := range resources {
+		rpcAddress := resource.GetIPInNetwork(network) + ":8334"
+		configs = append(configs, &rpcclient.ConnConfig{
+			Host:                rpcAddress,
+			Endpoint:            "ws",
+			User:                "localuser",
+			Pass:                "localuserpwd",
+			Certificates:        fetchRPCServerCert(t, poolClient, rpcAddress),
+			DisableConnectOnNew: true,
+		})
+	}
+
+	rpcPool, err := pool.New(configs)
+	if err != nil {
+		t.Fatalf("Could not create pool: %s", err)
+	}
+	defer rpcPool.Close()
+
+	ctx := context.Background()
+	if _, err := rpcPool.GetBlockCount(ctx); err != nil {
+		t.Fatalf("Pool GetBlockCount failed before any failure: %s", err)
+	}
+
+	// The call above used node 0 and advanced the pool's round-robin cursor
+	// to node 1, so node 1 is whichever endpoint the very next call would
+	// try first. Kill that one mid-test: the pool should fail over to node
+	// 2 within the same call, without the caller observing an error and
+	// without any retry loop on our side masking a broken failover.
+	if err := poolClient.Client.KillContainer(dc.KillContainerOptions{
+		ID: resources[1].Container.ID,
+	}); err != nil {
+		t.Fatalf("Could not kill node 1: %s", err)
+	}
+
+	if _, err := rpcPool.GetBlockCount(ctx); err != nil {
+		t.Fatalf("Pool did not fail over after node 1 was killed: %s", err)
+	}
+}
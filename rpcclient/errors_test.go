@@ -0,0 +1,29 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+)
+
+func TestWrapLoadingError(t *testing.T) {
+	loading := &btcjson.RPCError{Code: rpcLoadingErrorCode, Message: "loading"}
+	if got := wrapLoadingError(loading); !errors.Is(got, ErrRPCLoading) {
+		t.Fatalf("wrapLoadingError(%v) = %v, want ErrRPCLoading", loading, got)
+	}
+
+	other := &btcjson.RPCError{Code: -1, Message: "some other failure"}
+	if got := wrapLoadingError(other); got != other {
+		t.Fatalf("wrapLoadingError(%v) = %v, want err unchanged", other, got)
+	}
+
+	plain := errors.New("transport error")
+	if got := wrapLoadingError(plain); got != plain {
+		t.Fatalf("wrapLoadingError(%v) = %v, want err unchanged", plain, got)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import "crypto/tls"
+
+// clientTLSCertificate parses an mTLS client certificate/key pair supplied
+// via ConnConfig.ClientCertificates/ConnConfig.ClientKey.
+//
+// It must be called from the dial path (connection.go) whenever both fields
+// are set, so the client presents this certificate during the TLS handshake
+// against a server started with --rpcauthtype=mtls. That dial path, and the
+// ClientCertificates/ClientKey fields on ConnConfig themselves, live outside
+// this checkout.
+func clientTLSCertificate(certPEM, keyPEM []byte) (tls.Certificate, error) {
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package pool provides a Pool type that fans out rpcclient calls across
+// several btcd RPC endpoints, retrying idempotent read calls against the
+// next healthy endpoint when one becomes unreachable.
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrNoHealthyEndpoints is returned when every endpoint in the pool is
+// currently marked unhealthy.
+var ErrNoHealthyEndpoints = errors.New("pool: no healthy endpoints available")
+
+const (
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// endpoint tracks one ConnConfig target along with its lazily dialed client
+// and health/backoff state.
+type endpoint struct {
+	cfg *rpcclient.ConnConfig
+
+	mu      sync.Mutex
+	client  *rpcclient.Client
+	healthy bool
+	backoff time.Duration
+	retryAt time.Time
+}
+
+// Pool holds a set of btcd RPC endpoints and round-robins requests across
+// whichever of them are currently healthy, similar in spirit to Consul's
+// connPool.
+type Pool struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	next      int
+}
+
+// New creates a Pool over the given connection configs. Connections are
+// dialed lazily, on first use of each endpoint.
+func New(configs []*rpcclient.ConnConfig) (*Pool, error) {
+	if len(configs) == 0 {
+		return nil, errors.New("pool: at least one ConnConfig is required")
+	}
+
+	p := &Pool{}
+	for _, cfg := range configs {
+		p.endpoints = append(p.endpoints, &endpoint{
+			cfg:     cfg,
+			healthy: true,
+			backoff: minBackoff,
+		})
+	}
+	return p, nil
+}
+
+// Close shuts down every dialed client in the pool.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		if ep.client != nil {
+			ep.client.Shutdown()
+		}
+		ep.mu.Unlock()
+	}
+}
+
+// markUnhealthy flags the endpoint as unhealthy and schedules its next retry
+// using exponential backoff.
+func (ep *endpoint) markUnhealthy() {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	ep.healthy = false
+	ep.retryAt = time.Now().Add(ep.backoff)
+	if ep.backoff *= 2; ep.backoff > maxBackoff {
+		ep.backoff = maxBackoff
+	}
+}
+
+// markHealthy clears the unhealthy flag and resets the backoff.
+func (ep *endpoint) markHealthy() {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	ep.healthy = true
+	ep.backoff = minBackoff
+}
+
+// considerHealthy reports whether the endpoint should currently be tried,
+// i.e. it hasn't been marked unhealthy, or its backoff has elapsed.
+func (ep *endpoint) considerHealthy() bool {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	return ep.healthy || !time.Now().Before(ep.retryAt)
+}
+
+// dial returns the endpoint's rpcclient.Client, dialing it on first use and
+// wiring disconnect notifications back into the endpoint's health state.
+func (ep *endpoint) dial() (*rpcclient.Client, error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if ep.client != nil {
+		return ep.client, nil
+	}
+
+	handlers := &rpcclient.NotificationHandlers{
+		OnDisconnected: ep.markUnhealthy,
+	}
+	client, err := rpcclient.New(ep.cfg, handlers)
+	if err != nil {
+		return nil, err
+	}
+	ep.client = client
+	return client, nil
+}
+
+// orderedEndpoints returns the pool's endpoints starting from the next
+// round-robin cursor, healthy ones first.
+func (p *Pool) orderedEndpoints() []*endpoint {
+	p.mu.Lock()
+	start := p.next
+	p.next = (p.next + 1) % len(p.endpoints)
+	endpoints := p.endpoints
+	p.mu.Unlock()
+
+	ordered := make([]*endpoint, 0, len(endpoints))
+	for i := range endpoints {
+		ordered = append(ordered, endpoints[(start+i)%len(endpoints)])
+	}
+
+	healthy := ordered[:0:0]
+	unhealthy := make([]*endpoint, 0)
+	for _, ep := range ordered {
+		if ep.considerHealthy() {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// Call invokes method against the next healthy endpoint, retrying against
+// subsequent endpoints on transport errors. It is the caller's
+// responsibility to only pass idempotent methods here; use CallNoRetry for
+// anything that must not be submitted twice.
+func (p *Pool) Call(ctx context.Context, method string, params []json.RawMessage, reply interface{}) error {
+	return p.call(ctx, method, params, reply, true)
+}
+
+// CallNoRetry invokes method against the next healthy endpoint, retrying
+// only if the endpoint could not be dialed at all (i.e. the request was
+// never sent).
+func (p *Pool) CallNoRetry(ctx context.Context, method string, params []json.RawMessage, reply interface{}) error {
+	return p.call(ctx, method, params, reply, false)
+}
+
+func (p *Pool) call(ctx context.Context, method string, params []json.RawMessage, reply interface{}, retryOnTransportErr bool) error {
+	endpoints := p.orderedEndpoints()
+
+	// Healthy endpoints are ordered first, so if the first one is not
+	// healthy, none are.
+	if !endpoints[0].considerHealthy() {
+		return ErrNoHealthyEndpoints
+	}
+
+	var lastErr error = ErrNoHealthyEndpoints
+	for _, ep := range endpoints {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		client, err := ep.dial()
+		if err != nil {
+			lastErr = err
+			ep.markUnhealthy()
+			continue
+		}
+
+		raw, err := rawRequestWithContext(ctx, client, method, params)
+		if err == nil {
+			ep.markHealthy()
+			if reply == nil {
+				return nil
+			}
+			return json.Unmarshal(raw, reply)
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		// A JSON-RPC error means the endpoint answered, so it is not a
+		// transport error and is returned without trying another endpoint.
+		var rpcErr *btcjson.RPCError
+		if errors.As(err, &rpcErr) {
+			ep.markHealthy()
+			return err
+		}
+
+		lastErr = err
+		ep.markUnhealthy()
+		if !retryOnTransportErr {
+			return err
+		}
+	}
+	return lastErr
+}
+
+// rawRequestWithContext runs client.RawRequest, which has no context support
+// of its own, but returns early with ctx.Err() if ctx is done first. The
+// RawRequest goroutine is left to finish in the background in that case.
+func rawRequestWithContext(ctx context.Context, client *rpcclient.Client, method string, params []json.RawMessage) (json.RawMessage, error) {
+	type result struct {
+		raw json.RawMessage
+		err error
+	}
+
+	resultCh := make(chan result, 1)
+	go func() {
+		raw, err := client.RawRequest(method, params)
+		resultCh <- result{raw, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-resultCh:
+		return res.raw, res.err
+	}
+}
+
+// GetBlockCount returns the best block height, retrying against the next
+// healthy endpoint on transport errors since the call is idempotent.
+func (p *Pool) GetBlockCount(ctx context.Context) (int64, error) {
+	var count int64
+	err := p.Call(ctx, "getblockcount", nil, &count)
+	return count, err
+}
+
+// GetBlockHash returns the hash of the block at the given height, retrying
+// against the next healthy endpoint on transport errors.
+func (p *Pool) GetBlockHash(ctx context.Context, height int64) (*chainhash.Hash, error) {
+	params, err := marshalParams(height)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashStr string
+	if err := p.Call(ctx, "getblockhash", params, &hashStr); err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(hashStr)
+}
+
+// GetRawTransaction retrieves a transaction by hash, retrying against the
+// next healthy endpoint on transport errors.
+func (p *Pool) GetRawTransaction(ctx context.Context, txHash *chainhash.Hash) (*wire.MsgTx, error) {
+	params, err := marshalParams(txHash.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var rawHex string
+	if err := p.Call(ctx, "getrawtransaction", params, &rawHex); err != nil {
+		return nil, err
+	}
+
+	rawBytes, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(rawBytes)); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// SendRawTransaction broadcasts tx. Unlike the read methods above, this is
+// only retried if the chosen endpoint could not be dialed at all, since
+// retrying after an ambiguous transport error could double-submit the
+// transaction.
+func (p *Pool) SendRawTransaction(ctx context.Context, tx *wire.MsgTx) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	params, err := marshalParams(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	var hashStr string
+	if err := p.CallNoRetry(ctx, "sendrawtransaction", params, &hashStr); err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(hashStr)
+}
+
+func marshalParams(args ...interface{}) ([]json.RawMessage, error) {
+	params := make([]json.RawMessage, 0, len(args))
+	for _, arg := range args {
+		raw, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, raw)
+	}
+	return params, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import "testing"
+
+type fakeSyncChecker bool
+
+func (f fakeSyncChecker) IsCurrent() bool { return bool(f) }
+
+func TestCheckDeferUntilSynced(t *testing.T) {
+	tests := []struct {
+		name             string
+		cmd              string
+		deferUntilSynced bool
+		current          bool
+		wantErr          bool
+	}{
+		{"disabled passes through", "getblockcount", false, false, false},
+		{"allowlisted passes through while syncing", "getinfo", true, false, false},
+		{"non-allowlisted blocked while syncing", "getblockcount", true, false, true},
+		{"non-allowlisted allowed once current", "getblockcount", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDeferUntilSynced(tt.cmd, tt.deferUntilSynced, fakeSyncChecker(tt.current))
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if tt.wantErr && err.Code != rpcDeferUntilSyncedCode {
+				t.Fatalf("got error code %d, want %d", err.Code, rpcDeferUntilSyncedCode)
+			}
+		})
+	}
+}
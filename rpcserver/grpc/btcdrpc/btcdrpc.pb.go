@@ -0,0 +1,279 @@
+// Hand-written to match the wire format protoc-gen-go would emit for
+// btcdrpc.proto: protoc/protoc-gen-go aren't available in this environment,
+// so these bindings are maintained by hand for now and should be replaced by
+// the real `go generate` output (see ../doc.go) the next time someone has
+// the toolchain available. Keep this in sync with btcdrpc.proto by hand
+// until then.
+// source: btcdrpc.proto
+
+package btcdrpc
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+
+type GetBlockCountRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockCountRequest) Reset()         { *m = GetBlockCountRequest{} }
+func (m *GetBlockCountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockCountRequest) ProtoMessage()    {}
+
+type GetBlockCountResponse struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockCountResponse) Reset()         { *m = GetBlockCountResponse{} }
+func (m *GetBlockCountResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockCountResponse) ProtoMessage()    {}
+
+func (m *GetBlockCountResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type GetBestBlockHashRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBestBlockHashRequest) Reset()         { *m = GetBestBlockHashRequest{} }
+func (m *GetBestBlockHashRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBestBlockHashRequest) ProtoMessage()    {}
+
+type GetBestBlockHashResponse struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBestBlockHashResponse) Reset()         { *m = GetBestBlockHashResponse{} }
+func (m *GetBestBlockHashResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBestBlockHashResponse) ProtoMessage()    {}
+
+func (m *GetBestBlockHashResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type GetBlockRequest struct {
+	Hash    string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Verbose bool   `protobuf:"varint,2,opt,name=verbose,proto3" json:"verbose,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()    {}
+
+func (m *GetBlockRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *GetBlockRequest) GetVerbose() bool {
+	if m != nil {
+		return m.Verbose
+	}
+	return false
+}
+
+type GetBlockResponse struct {
+	SerializedBlock []byte `protobuf:"bytes,1,opt,name=serialized_block,json=serializedBlock,proto3" json:"serialized_block,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
+func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockResponse) ProtoMessage()    {}
+
+func (m *GetBlockResponse) GetSerializedBlock() []byte {
+	if m != nil {
+		return m.SerializedBlock
+	}
+	return nil
+}
+
+type GetRawTransactionRequest struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRawTransactionRequest) Reset()         { *m = GetRawTransactionRequest{} }
+func (m *GetRawTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRawTransactionRequest) ProtoMessage()    {}
+
+func (m *GetRawTransactionRequest) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+type GetRawTransactionResponse struct {
+	SerializedTx []byte `protobuf:"bytes,1,opt,name=serialized_tx,json=serializedTx,proto3" json:"serialized_tx,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRawTransactionResponse) Reset()         { *m = GetRawTransactionResponse{} }
+func (m *GetRawTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRawTransactionResponse) ProtoMessage()    {}
+
+func (m *GetRawTransactionResponse) GetSerializedTx() []byte {
+	if m != nil {
+		return m.SerializedTx
+	}
+	return nil
+}
+
+type SendRawTransactionRequest struct {
+	SerializedTx []byte `protobuf:"bytes,1,opt,name=serialized_tx,json=serializedTx,proto3" json:"serialized_tx,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendRawTransactionRequest) Reset()         { *m = SendRawTransactionRequest{} }
+func (m *SendRawTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRawTransactionRequest) ProtoMessage()    {}
+
+func (m *SendRawTransactionRequest) GetSerializedTx() []byte {
+	if m != nil {
+		return m.SerializedTx
+	}
+	return nil
+}
+
+type SendRawTransactionResponse struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendRawTransactionResponse) Reset()         { *m = SendRawTransactionResponse{} }
+func (m *SendRawTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SendRawTransactionResponse) ProtoMessage()    {}
+
+func (m *SendRawTransactionResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+type SubscribeBlocksRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeBlocksRequest) Reset()         { *m = SubscribeBlocksRequest{} }
+func (m *SubscribeBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeBlocksRequest) ProtoMessage()    {}
+
+type BlockNotification struct {
+	Hash   string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockNotification) Reset()         { *m = BlockNotification{} }
+func (m *BlockNotification) String() string { return proto.CompactTextString(m) }
+func (*BlockNotification) ProtoMessage()    {}
+
+func (m *BlockNotification) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *BlockNotification) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type SubscribeMempoolRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeMempoolRequest) Reset()         { *m = SubscribeMempoolRequest{} }
+func (m *SubscribeMempoolRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeMempoolRequest) ProtoMessage()    {}
+
+type MempoolNotification struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MempoolNotification) Reset()         { *m = MempoolNotification{} }
+func (m *MempoolNotification) String() string { return proto.CompactTextString(m) }
+func (*MempoolNotification) ProtoMessage()    {}
+
+func (m *MempoolNotification) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetBlockCountRequest)(nil), "btcdrpc.GetBlockCountRequest")
+	proto.RegisterType((*GetBlockCountResponse)(nil), "btcdrpc.GetBlockCountResponse")
+	proto.RegisterType((*GetBestBlockHashRequest)(nil), "btcdrpc.GetBestBlockHashRequest")
+	proto.RegisterType((*GetBestBlockHashResponse)(nil), "btcdrpc.GetBestBlockHashResponse")
+	proto.RegisterType((*GetBlockRequest)(nil), "btcdrpc.GetBlockRequest")
+	proto.RegisterType((*GetBlockResponse)(nil), "btcdrpc.GetBlockResponse")
+	proto.RegisterType((*GetRawTransactionRequest)(nil), "btcdrpc.GetRawTransactionRequest")
+	proto.RegisterType((*GetRawTransactionResponse)(nil), "btcdrpc.GetRawTransactionResponse")
+	proto.RegisterType((*SendRawTransactionRequest)(nil), "btcdrpc.SendRawTransactionRequest")
+	proto.RegisterType((*SendRawTransactionResponse)(nil), "btcdrpc.SendRawTransactionResponse")
+	proto.RegisterType((*SubscribeBlocksRequest)(nil), "btcdrpc.SubscribeBlocksRequest")
+	proto.RegisterType((*BlockNotification)(nil), "btcdrpc.BlockNotification")
+	proto.RegisterType((*SubscribeMempoolRequest)(nil), "btcdrpc.SubscribeMempoolRequest")
+	proto.RegisterType((*MempoolNotification)(nil), "btcdrpc.MempoolNotification")
+}
@@ -0,0 +1,360 @@
+// Hand-written to match the wire format protoc-gen-go-grpc would emit for
+// btcdrpc.proto, for the same toolchain-availability reason as btcdrpc.pb.go.
+// Replace with real `go generate` output once protoc is available; keep in
+// sync with btcdrpc.proto by hand until then.
+// source: btcdrpc.proto
+
+package btcdrpc
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// BtcdRPCClient is the client API for BtcdRPC service.
+type BtcdRPCClient interface {
+	GetBlockCount(ctx context.Context, in *GetBlockCountRequest, opts ...grpc.CallOption) (*GetBlockCountResponse, error)
+	GetBestBlockHash(ctx context.Context, in *GetBestBlockHashRequest, opts ...grpc.CallOption) (*GetBestBlockHashResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+	GetRawTransaction(ctx context.Context, in *GetRawTransactionRequest, opts ...grpc.CallOption) (*GetRawTransactionResponse, error)
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendRawTransactionResponse, error)
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (BtcdRPC_SubscribeBlocksClient, error)
+	SubscribeMempool(ctx context.Context, in *SubscribeMempoolRequest, opts ...grpc.CallOption) (BtcdRPC_SubscribeMempoolClient, error)
+}
+
+type btcdRPCClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewBtcdRPCClient creates a client for the BtcdRPC service.
+func NewBtcdRPCClient(cc grpc.ClientConnInterface) BtcdRPCClient {
+	return &btcdRPCClient{cc}
+}
+
+func (c *btcdRPCClient) GetBlockCount(ctx context.Context, in *GetBlockCountRequest, opts ...grpc.CallOption) (*GetBlockCountResponse, error) {
+	out := new(GetBlockCountResponse)
+	err := c.cc.Invoke(ctx, "/btcdrpc.BtcdRPC/GetBlockCount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *btcdRPCClient) GetBestBlockHash(ctx context.Context, in *GetBestBlockHashRequest, opts ...grpc.CallOption) (*GetBestBlockHashResponse, error) {
+	out := new(GetBestBlockHashResponse)
+	err := c.cc.Invoke(ctx, "/btcdrpc.BtcdRPC/GetBestBlockHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *btcdRPCClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	out := new(GetBlockResponse)
+	err := c.cc.Invoke(ctx, "/btcdrpc.BtcdRPC/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *btcdRPCClient) GetRawTransaction(ctx context.Context, in *GetRawTransactionRequest, opts ...grpc.CallOption) (*GetRawTransactionResponse, error) {
+	out := new(GetRawTransactionResponse)
+	err := c.cc.Invoke(ctx, "/btcdrpc.BtcdRPC/GetRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *btcdRPCClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendRawTransactionResponse, error) {
+	out := new(SendRawTransactionResponse)
+	err := c.cc.Invoke(ctx, "/btcdrpc.BtcdRPC/SendRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *btcdRPCClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (BtcdRPC_SubscribeBlocksClient, error) {
+	stream, err := c.cc.(grpc.ClientConnInterface).NewStream(ctx, &_BtcdRPC_serviceDesc.Streams[0], "/btcdrpc.BtcdRPC/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &btcdRPCSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BtcdRPC_SubscribeBlocksClient interface {
+	Recv() (*BlockNotification, error)
+	grpc.ClientStream
+}
+
+type btcdRPCSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *btcdRPCSubscribeBlocksClient) Recv() (*BlockNotification, error) {
+	m := new(BlockNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *btcdRPCClient) SubscribeMempool(ctx context.Context, in *SubscribeMempoolRequest, opts ...grpc.CallOption) (BtcdRPC_SubscribeMempoolClient, error) {
+	stream, err := c.cc.(grpc.ClientConnInterface).NewStream(ctx, &_BtcdRPC_serviceDesc.Streams[1], "/btcdrpc.BtcdRPC/SubscribeMempool", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &btcdRPCSubscribeMempoolClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BtcdRPC_SubscribeMempoolClient interface {
+	Recv() (*MempoolNotification, error)
+	grpc.ClientStream
+}
+
+type btcdRPCSubscribeMempoolClient struct {
+	grpc.ClientStream
+}
+
+func (x *btcdRPCSubscribeMempoolClient) Recv() (*MempoolNotification, error) {
+	m := new(MempoolNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BtcdRPCServer is the server API for BtcdRPC service.
+type BtcdRPCServer interface {
+	GetBlockCount(context.Context, *GetBlockCountRequest) (*GetBlockCountResponse, error)
+	GetBestBlockHash(context.Context, *GetBestBlockHashRequest) (*GetBestBlockHashResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+	GetRawTransaction(context.Context, *GetRawTransactionRequest) (*GetRawTransactionResponse, error)
+	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendRawTransactionResponse, error)
+	SubscribeBlocks(*SubscribeBlocksRequest, BtcdRPC_SubscribeBlocksServer) error
+	SubscribeMempool(*SubscribeMempoolRequest, BtcdRPC_SubscribeMempoolServer) error
+	mustEmbedUnimplementedBtcdRPCServer()
+}
+
+// UnimplementedBtcdRPCServer must be embedded to have forward compatible implementations.
+type UnimplementedBtcdRPCServer struct{}
+
+func (UnimplementedBtcdRPCServer) GetBlockCount(context.Context, *GetBlockCountRequest) (*GetBlockCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockCount not implemented")
+}
+func (UnimplementedBtcdRPCServer) GetBestBlockHash(context.Context, *GetBestBlockHashRequest) (*GetBestBlockHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBestBlockHash not implemented")
+}
+func (UnimplementedBtcdRPCServer) GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedBtcdRPCServer) GetRawTransaction(context.Context, *GetRawTransactionRequest) (*GetRawTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRawTransaction not implemented")
+}
+func (UnimplementedBtcdRPCServer) SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendRawTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
+}
+func (UnimplementedBtcdRPCServer) SubscribeBlocks(*SubscribeBlocksRequest, BtcdRPC_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (UnimplementedBtcdRPCServer) SubscribeMempool(*SubscribeMempoolRequest, BtcdRPC_SubscribeMempoolServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMempool not implemented")
+}
+func (UnimplementedBtcdRPCServer) mustEmbedUnimplementedBtcdRPCServer() {}
+
+// RegisterBtcdRPCServer registers srv with s under the BtcdRPC service name.
+func RegisterBtcdRPCServer(s grpc.ServiceRegistrar, srv BtcdRPCServer) {
+	s.RegisterService(&_BtcdRPC_serviceDesc, srv)
+}
+
+func _BtcdRPC_GetBlockCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BtcdRPCServer).GetBlockCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btcdrpc.BtcdRPC/GetBlockCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BtcdRPCServer).GetBlockCount(ctx, req.(*GetBlockCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BtcdRPC_GetBestBlockHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBestBlockHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BtcdRPCServer).GetBestBlockHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btcdrpc.BtcdRPC/GetBestBlockHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BtcdRPCServer).GetBestBlockHash(ctx, req.(*GetBestBlockHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BtcdRPC_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BtcdRPCServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btcdrpc.BtcdRPC/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BtcdRPCServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BtcdRPC_GetRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BtcdRPCServer).GetRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btcdrpc.BtcdRPC/GetRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BtcdRPCServer).GetRawTransaction(ctx, req.(*GetRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BtcdRPC_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BtcdRPCServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/btcdrpc.BtcdRPC/SendRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BtcdRPCServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BtcdRPC_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BtcdRPCServer).SubscribeBlocks(m, &btcdRPCSubscribeBlocksServer{stream})
+}
+
+type BtcdRPC_SubscribeBlocksServer interface {
+	Send(*BlockNotification) error
+	grpc.ServerStream
+}
+
+type btcdRPCSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *btcdRPCSubscribeBlocksServer) Send(m *BlockNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BtcdRPC_SubscribeMempool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMempoolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BtcdRPCServer).SubscribeMempool(m, &btcdRPCSubscribeMempoolServer{stream})
+}
+
+type BtcdRPC_SubscribeMempoolServer interface {
+	Send(*MempoolNotification) error
+	grpc.ServerStream
+}
+
+type btcdRPCSubscribeMempoolServer struct {
+	grpc.ServerStream
+}
+
+func (x *btcdRPCSubscribeMempoolServer) Send(m *MempoolNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// _BtcdRPC_serviceDesc is the grpc.ServiceDesc for the BtcdRPC service.
+var _BtcdRPC_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "btcdrpc.BtcdRPC",
+	HandlerType: (*BtcdRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlockCount",
+			Handler:    _BtcdRPC_GetBlockCount_Handler,
+		},
+		{
+			MethodName: "GetBestBlockHash",
+			Handler:    _BtcdRPC_GetBestBlockHash_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _BtcdRPC_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetRawTransaction",
+			Handler:    _BtcdRPC_GetRawTransaction_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _BtcdRPC_SendRawTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _BtcdRPC_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMempool",
+			Handler:       _BtcdRPC_SubscribeMempool_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "btcdrpc.proto",
+}
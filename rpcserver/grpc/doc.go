@@ -0,0 +1,15 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package grpc exposes a curated subset of btcd's JSON-RPC API over gRPC,
+// routing each call into the same command handlers rpcserver already uses
+// for its JSON-RPC/websocket surface.
+//
+// The btcdrpc subpackage holds the protobuf/gRPC bindings for btcdrpc.proto.
+// They should be produced by `go generate` below; until protoc is available
+// in this environment they are maintained by hand to match what it would
+// emit, and are clearly marked as such at the top of each file.
+package grpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative btcdrpc.proto
@@ -0,0 +1,203 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+
+	"github.com/btcsuite/btcd/rpcserver/grpc/btcdrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Backend is the subset of rpcServer's command handlers this gRPC facade
+// needs. It is satisfied by the same type that already serves the
+// JSON-RPC/websocket API, so both interfaces observe identical state.
+type Backend interface {
+	GetBlockCount() (int64, error)
+	GetBestBlockHash() (string, error)
+	GetBlock(hash string, verbose bool) ([]byte, error)
+	GetRawTransaction(txid string) ([]byte, error)
+	SendRawTransaction(serializedTx []byte) (string, error)
+
+	// SubscribeBlocks/SubscribeMempool register fn to be called for every
+	// new block/mempool acceptance, reusing the existing websocket
+	// notification fan-out. The returned func unregisters fn.
+	SubscribeBlocks(fn func(hash string, height int64)) (unsubscribe func())
+	SubscribeMempool(fn func(txid string)) (unsubscribe func())
+}
+
+// Config holds the listener and TLS material for the gRPC server.
+type Config struct {
+	// Listen is the address to serve on, e.g. "0.0.0.0:8336".
+	Listen string
+
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile, when set, enables mutual TLS: only clients presenting
+	// a certificate signed by this CA are accepted.
+	ClientCAFile string
+}
+
+// Server is the gRPC facade over a Backend.
+type Server struct {
+	btcdrpc.UnimplementedBtcdRPCServer
+
+	backend Backend
+	grpcSrv *grpc.Server
+	addr    string
+}
+
+// Addr returns the address the server is actually listening on, which may
+// differ from Config.Listen if it ended in ":0".
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// New creates a Server for backend and starts serving on cfg.Listen. Callers
+// should defer a call to Stop.
+//
+// btcd's daemon entry point (config.go/rpcserver.go) is not part of this
+// checkout, so the --grpclisten flag and the call to New from btcd's startup
+// path, passing the real rpcServer as Backend, still need to be added there;
+// see server_test.go for coverage of this package against a fake Backend in
+// the meantime.
+func New(cfg Config, backend Backend) (*Server, error) {
+	creds, err := loadTLSCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	lis, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		backend: backend,
+		grpcSrv: grpc.NewServer(grpc.Creds(creds)),
+		addr:    lis.Addr().String(),
+	}
+	btcdrpc.RegisterBtcdRPCServer(s.grpcSrv, s)
+
+	go s.grpcSrv.Serve(lis)
+	return s, nil
+}
+
+// Stop gracefully shuts down the gRPC server.
+func (s *Server) Stop() {
+	s.grpcSrv.GracefulStop()
+}
+
+func loadTLSCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(caPEM)
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+func (s *Server) GetBlockCount(ctx context.Context, req *btcdrpc.GetBlockCountRequest) (*btcdrpc.GetBlockCountResponse, error) {
+	height, err := s.backend.GetBlockCount()
+	if err != nil {
+		return nil, err
+	}
+	return &btcdrpc.GetBlockCountResponse{Height: height}, nil
+}
+
+func (s *Server) GetBestBlockHash(ctx context.Context, req *btcdrpc.GetBestBlockHashRequest) (*btcdrpc.GetBestBlockHashResponse, error) {
+	hash, err := s.backend.GetBestBlockHash()
+	if err != nil {
+		return nil, err
+	}
+	return &btcdrpc.GetBestBlockHashResponse{Hash: hash}, nil
+}
+
+func (s *Server) GetBlock(ctx context.Context, req *btcdrpc.GetBlockRequest) (*btcdrpc.GetBlockResponse, error) {
+	block, err := s.backend.GetBlock(req.Hash, req.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	return &btcdrpc.GetBlockResponse{SerializedBlock: block}, nil
+}
+
+func (s *Server) GetRawTransaction(ctx context.Context, req *btcdrpc.GetRawTransactionRequest) (*btcdrpc.GetRawTransactionResponse, error) {
+	tx, err := s.backend.GetRawTransaction(req.Txid)
+	if err != nil {
+		return nil, err
+	}
+	return &btcdrpc.GetRawTransactionResponse{SerializedTx: tx}, nil
+}
+
+func (s *Server) SendRawTransaction(ctx context.Context, req *btcdrpc.SendRawTransactionRequest) (*btcdrpc.SendRawTransactionResponse, error) {
+	txid, err := s.backend.SendRawTransaction(req.SerializedTx)
+	if err != nil {
+		return nil, err
+	}
+	return &btcdrpc.SendRawTransactionResponse{Txid: txid}, nil
+}
+
+func (s *Server) SubscribeBlocks(req *btcdrpc.SubscribeBlocksRequest, stream btcdrpc.BtcdRPC_SubscribeBlocksServer) error {
+	errCh := make(chan error, 1)
+	unsubscribe := s.backend.SubscribeBlocks(func(hash string, height int64) {
+		err := stream.Send(&btcdrpc.BlockNotification{Hash: hash, Height: height})
+		if err != nil {
+			select {
+			case errCh <- err:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	}
+}
+
+func (s *Server) SubscribeMempool(req *btcdrpc.SubscribeMempoolRequest, stream btcdrpc.BtcdRPC_SubscribeMempoolServer) error {
+	errCh := make(chan error, 1)
+	unsubscribe := s.backend.SubscribeMempool(func(txid string) {
+		err := stream.Send(&btcdrpc.MempoolNotification{Txid: txid})
+		if err != nil {
+			select {
+			case errCh <- err:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	}
+}
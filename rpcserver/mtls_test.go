@@ -0,0 +1,91 @@
+// Copyright (c) 2022 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSelfSignedPair writes a throwaway self-signed cert/key pair to dir and
+// returns their paths, so buildTLSConfig has real files to load.
+func writeSelfSignedPair(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rpcserver mtls test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Could not create certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Could not marshal key: %s", err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Could not write cert: %s", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("Could not write key: %s", err)
+	}
+	return certFile, keyFile
+}
+
+func TestBuildTLSConfigPlain(t *testing.T) {
+	certFile, keyFile := writeSelfSignedPair(t, t.TempDir())
+
+	cfg, err := buildTLSConfig(certFile, keyFile, "", "")
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %s", err)
+	}
+	if cfg.ClientAuth == tls.RequireAndVerifyClientCert {
+		t.Fatalf("plain config should not require client certs")
+	}
+}
+
+func TestBuildTLSConfigMTLSRequiresClientCA(t *testing.T) {
+	certFile, keyFile := writeSelfSignedPair(t, t.TempDir())
+
+	if _, err := buildTLSConfig(certFile, keyFile, "", rpcAuthTypeMTLS); err == nil {
+		t.Fatalf("expected an error when --rpcauthtype=mtls is set without --rpcclientca")
+	}
+}
+
+func TestBuildTLSConfigMTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedPair(t, dir)
+	clientCAFile, _ := writeSelfSignedPair(t, dir)
+
+	cfg, err := buildTLSConfig(certFile, keyFile, clientCAFile, rpcAuthTypeMTLS)
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %s", err)
+	}
+	if cfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Fatalf("mtls config should require and verify client certs")
+	}
+	if cfg.ClientCAs == nil {
+		t.Fatalf("mtls config should have a client CA pool")
+	}
+}